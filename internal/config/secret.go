@@ -77,6 +77,7 @@ var wellknownFingerprints = []string{
 //   - "github_app_auth" for GitHub App authentication. Values for keys "integration_id", "installation_id", and "private_key" are expected.
 //   - "password" for password authentication. Value for key "password" is expected.
 //   - "ssh_key" for SSH private key authentication. Value for key "key" (private key) is expected. "fingerprints" (string array) and "passphrase" are optional.
+//   - "ssh_agent" for SSH authentication through the agent listening on SSH_AUTH_SOCK. "user" and "fingerprints" (string array) are optional.
 //   - "token_auth" for HTTP bearer token authentication. Value for a key "token" is expected.
 type Secret struct {
 	Name  string         `json:"-"`
@@ -211,6 +212,19 @@ func (s *Secret) Typed(context.Context) (any, error) {
 
 		return value, nil
 
+	case "ssh_agent":
+		var value SecretSSHAgent
+		if err := decode(m, &value); err != nil {
+			return nil, err
+		}
+
+		// If no fingerprints are provided, use well-known ones for popular services.
+		if len(value.Fingerprints) == 0 {
+			value.Fingerprints = wellknownFingerprints
+		}
+
+		return value, nil
+
 	case "basic_auth":
 		var value SecretBasicAuth
 		if err := decode(m, &value); err != nil {
@@ -292,6 +306,11 @@ type SecretSSHKey struct {
 	Fingerprints []string `json:"fingerprints,omitempty"` // Optional SSH key fingerprints.
 }
 
+type SecretSSHAgent struct {
+	User         string   `json:"user,omitempty"`         // Optional SSH user, defaults to "git".
+	Fingerprints []string `json:"fingerprints,omitempty"` // Optional SSH key fingerprints.
+}
+
 type SecretBasicAuth struct {
 	Username string   `json:"username"`
 	Password string   `json:"password"`
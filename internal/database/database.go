@@ -680,21 +680,88 @@ func (d *Database) GetBundle(ctx context.Context, principal, tenant, name string
 
 func (d *Database) DeleteBundle(ctx context.Context, principal, tenant, name string) error {
 	return tx1(ctx, d, func(tx *sql.Tx) error {
-		if err := d.prepareDelete(ctx, tx, principal, tenant, "bundles", name, "bundles.manage"); err != nil {
+		return d.deleteBundle(ctx, tx, principal, tenant, name)
+	})
+}
+
+// DeleteBundlesBySelector deletes all bundles of the tenant whose labels match the
+// selector in a single transaction, and returns the names of the deleted bundles.
+// Matching bundles the principal is not permitted to manage are skipped if
+// skipUnauthorized is set; otherwise, nothing is deleted and ErrNotAuthorized is
+// returned.
+func (d *Database) DeleteBundlesBySelector(ctx context.Context, principal, tenant string, selector config.Selector, skipUnauthorized bool) ([]string, error) {
+	var deleted []string
+	err := tx1(ctx, d, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT bundles.name, bundles.labels
+FROM bundles
+JOIN tenants ON bundles.tenant_id = tenants.id
+WHERE tenants.name = `+d.arg(0)+`
+ORDER BY bundles.id`, tenant)
+		if err != nil {
 			return err
 		}
-		id, err := d.lookupID(ctx, tx, tenant, "bundles", name)
-		if err != nil {
-			return fmt.Errorf("lookup bundle %s: %w", name, err)
+
+		var matched []string
+		for rows.Next() {
+			var name string
+			var labelsJSON *string
+			if err := rows.Scan(&name, &labelsJSON); err != nil {
+				rows.Close()
+				return err
+			}
+			var labels config.Labels
+			if labelsJSON != nil {
+				if err := json.Unmarshal([]byte(*labelsJSON), &labels); err != nil {
+					rows.Close()
+					return fmt.Errorf("failed to unmarshal labels for %q: %w", name, err)
+				}
+			}
+			if selector.Matches(labels) {
+				matched = append(matched, name)
+			}
 		}
-		if err := d.delete(ctx, tx, "bundles_secrets", "bundle_id", id); err != nil {
+		if err := rows.Close(); err != nil {
 			return err
 		}
-		if err := d.delete(ctx, tx, "bundles_requirements", "bundle_id", id); err != nil {
+		if err := rows.Err(); err != nil {
 			return err
 		}
-		return d.delete(ctx, tx, "bundles", "id", id)
+
+		for _, name := range matched {
+			err := d.deleteBundle(ctx, tx, principal, tenant, name)
+			switch {
+			case errors.Is(err, ErrNotAuthorized) && skipUnauthorized:
+				continue
+			case errors.Is(err, ErrNotAuthorized):
+				return err // don't reveal names of bundles the principal may not see
+			case err != nil:
+				return fmt.Errorf("delete bundle %q: %w", name, err)
+			}
+			deleted = append(deleted, name)
+		}
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return deleted, nil
+}
+
+func (d *Database) deleteBundle(ctx context.Context, tx *sql.Tx, principal, tenant, name string) error {
+	if err := d.prepareDelete(ctx, tx, principal, tenant, "bundles", name, "bundles.manage"); err != nil {
+		return err
+	}
+	id, err := d.lookupID(ctx, tx, tenant, "bundles", name)
+	if err != nil {
+		return fmt.Errorf("lookup bundle %s: %w", name, err)
+	}
+	if err := d.delete(ctx, tx, "bundles_secrets", "bundle_id", id); err != nil {
+		return err
+	}
+	if err := d.delete(ctx, tx, "bundles_requirements", "bundle_id", id); err != nil {
+		return err
+	}
+	return d.delete(ctx, tx, "bundles", "id", id)
 }
 
 func (d *Database) ListBundles(ctx context.Context, principal, tenant string, opts ListOptions) ([]*config.Bundle, string, error) {
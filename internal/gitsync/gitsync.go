@@ -71,6 +71,10 @@ func init() {
 //   - passphrase (string, optional)
 //   - fingerprints ([]string, required) - SHA256 fingerprints
 //
+// Type: "ssh_agent" - uses the SSH agent listening on SSH_AUTH_SOCK
+//   - user (string, optional) - defaults to "git"
+//   - fingerprints ([]string, required) - SHA256 fingerprints
+//
 // Type: "bearer_token"
 //   - token (string, required)
 //
@@ -104,6 +108,7 @@ type Synchronizer struct {
 	gh             github
 	sourceName     string
 	secretProvider pkgsync.SecretProvider
+	refResolver    pkgsync.RefResolver
 	metrics        *metrics.Metrics
 }
 
@@ -122,6 +127,13 @@ func (s *Synchronizer) WithSecretProvider(provider pkgsync.SecretProvider) *Sync
 	return s
 }
 
+// WithRefResolver configures the synchronizer to resolve the configured reference through an
+// external RefResolver before cloning or checking out. Without a resolver, the reference is used as-is.
+func (s *Synchronizer) WithRefResolver(resolver pkgsync.RefResolver) *Synchronizer {
+	s.refResolver = resolver
+	return s
+}
+
 // WithMetrics configures the synchronizer to record git sync metrics.
 func (s *Synchronizer) WithMetrics(m *metrics.Metrics) *Synchronizer {
 	s.metrics = m
@@ -162,9 +174,16 @@ func (s *Synchronizer) execute(ctx context.Context) (bool, string, error) {
 		return false, "", errors.New("either reference or commit must be set in git configuration")
 	}
 
+	// NB: The resolved reference is only used for clone and checkout. The on-disk config tracks the
+	// unresolved one, so that a resolver returning a different commit does not trigger a re-clone.
+	resolved, err := s.resolveRef(ctx)
+	if err != nil {
+		return false, "", err
+	}
+
 	var referenceName plumbing.ReferenceName
-	if s.config.Reference != nil {
-		referenceName = plumbing.ReferenceName(*s.config.Reference)
+	if resolved.Reference != nil {
+		referenceName = plumbing.ReferenceName(*resolved.Reference)
 	}
 
 	// A configuration change may necessitate wiping an earlier clone: in particular, re-cloning
@@ -186,7 +205,6 @@ func (s *Synchronizer) execute(ctx context.Context) (bool, string, error) {
 	}
 
 	var authMethod transport.AuthMethod
-	var err error
 
 	repository, err := git.PlainOpen(s.path)
 	if errors.Is(err, git.ErrRepositoryNotExists) { // does not exist? clone it
@@ -224,10 +242,10 @@ func (s *Synchronizer) execute(ctx context.Context) (bool, string, error) {
 		return false, "", err
 	}
 
-	if s.config.Commit != nil {
+	if resolved.Commit != nil {
 		opts := &git.CheckoutOptions{
 			Force: true,
-			Hash:  plumbing.NewHash(*s.config.Commit),
+			Hash:  plumbing.NewHash(*resolved.Commit),
 		}
 		if w.Checkout(opts) == nil { // success! nothing further to do
 			head, err := repository.Head()
@@ -267,10 +285,10 @@ func (s *Synchronizer) execute(ctx context.Context) (bool, string, error) {
 		Force: true, // Discard any local changes
 	}
 	switch {
-	case s.config.Commit != nil:
-		opts.Hash = plumbing.NewHash(*s.config.Commit)
-	case s.config.Reference != nil:
-		ref := fmt.Sprintf("refs/remotes/%s/%s", remote, *s.config.Reference)
+	case resolved.Commit != nil:
+		opts.Hash = plumbing.NewHash(*resolved.Commit)
+	case resolved.Reference != nil:
+		ref := fmt.Sprintf("refs/remotes/%s/%s", remote, *resolved.Reference)
 		opts.Branch = plumbing.ReferenceName(ref)
 	}
 
@@ -285,6 +303,35 @@ func (s *Synchronizer) execute(ctx context.Context) (bool, string, error) {
 	return fetched, head.Hash().String(), nil
 }
 
+// resolveRef returns a copy of the git configuration with the reference resolved through the
+// configured RefResolver. If the resolver returns a commit hash, the reference is dropped and
+// the commit is checked out instead, unless a commit is already pinned (e.g. by a requirement).
+func (s *Synchronizer) resolveRef(ctx context.Context) (config.Git, error) {
+	cfg := s.config
+	if s.refResolver == nil || cfg.Reference == nil {
+		return cfg, nil
+	}
+
+	ref, err := s.refResolver.ResolveRef(ctx, *cfg.Reference)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to resolve reference %q: %w", *cfg.Reference, err)
+	}
+	if ref == "" {
+		return cfg, fmt.Errorf("failed to resolve reference %q: resolver returned empty reference", *cfg.Reference)
+	}
+
+	if plumbing.IsHash(ref) {
+		cfg.Reference = nil
+		if cfg.Commit == nil {
+			cfg.Commit = &ref
+		}
+	} else {
+		cfg.Reference = &ref
+	}
+
+	return cfg, nil
+}
+
 func (*Synchronizer) Close(context.Context) {
 	// No resources to close.
 }
@@ -340,6 +387,9 @@ func authFromTyped(ctx context.Context, gh *github, value any) (transport.AuthMe
 	case config.SecretSSHKey:
 		return newSSHAuth(value.Key, value.Passphrase, value.Fingerprints)
 
+	case config.SecretSSHAgent:
+		return newSSHAgentAuth(value.User, value.Fingerprints)
+
 	case *config.SecretOIDCClientCredentials:
 		// Use the TokenSecret interface for OAuth2 token-based authentication
 		return &tokenAuth{
@@ -433,6 +483,28 @@ func newSSHAuth(key string, passphrase string, fingerprints []string) (gitssh.Au
 	}, nil
 }
 
+func newSSHAgentAuth(user string, fingerprints []string) (gitssh.AuthMethod, error) {
+	if os.Getenv("SSH_AUTH_SOCK") == "" {
+		return nil, errors.New("ssh: SSH_AUTH_SOCK must be set when using ssh_agent authentication")
+	}
+
+	if len(fingerprints) == 0 {
+		return nil, errors.New("ssh: at least one fingerprint is required when using ssh_agent authentication")
+	}
+
+	if user == "" {
+		user = "git"
+	}
+
+	auth, err := gitssh.NewSSHAgentAuth(user)
+	if err != nil {
+		return nil, err
+	}
+
+	auth.HostKeyCallback = newCheckFingerprints(fingerprints)
+	return auth, nil
+}
+
 func newCheckFingerprints(fingerprints []string) ssh.HostKeyCallback {
 	m := make(map[string]bool, len(fingerprints))
 	for _, fp := range fingerprints {
@@ -8,9 +8,12 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -20,6 +23,7 @@ import (
 	"github.com/open-policy-agent/opa-control-plane/internal/gitsync"
 	"github.com/open-policy-agent/opa-control-plane/internal/syncerr"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 )
 
 // TestGitsyncLocal tests the functionality of the gitsync package by creating a temporary git repository on disk.
@@ -166,6 +170,112 @@ func TestGitsyncUserError(t *testing.T) {
 	})
 }
 
+// fakeRefResolver maps reference expressions to concrete references.
+type fakeRefResolver map[string]string
+
+func (f fakeRefResolver) ResolveRef(_ context.Context, reference string) (string, error) {
+	if !strings.HasPrefix(reference, "@channel:") {
+		return reference, nil
+	}
+	if resolved, ok := f[reference]; ok {
+		return resolved, nil
+	}
+	return "", fmt.Errorf("unknown channel %q", reference)
+}
+
+// TestGitsyncRefResolver verifies that the configured reference is resolved through the
+// RefResolver before clone and checkout, and that resolver failures fail the sync.
+func TestGitsyncRefResolver(t *testing.T) {
+	testRepositoryPath := t.TempDir() + "/testing"
+	repository, err := git.PlainInit(testRepositoryPath, false)
+	if err != nil {
+		t.Fatalf("expected no error while initializing test repository: %v", err)
+	}
+
+	w, err := repository.Worktree()
+	if err != nil {
+		t.Fatalf("expected no error while getting worktree: %v", err)
+	}
+
+	var commits []string
+	for _, content := range []string{"first commit", "second commit"} {
+		if err := os.WriteFile(testRepositoryPath+"/README", []byte(content), 0644); err != nil {
+			t.Fatalf("expected no error while creating new file: %v", err)
+		}
+		if _, err := w.Add("README"); err != nil {
+			t.Fatalf("expected no error while adding file to worktree: %v", err)
+		}
+		hash, err := w.Commit(content, &git.CommitOptions{Author: &object.Signature{}})
+		if err != nil {
+			t.Fatalf("expected no error while committing changes: %v", err)
+		}
+		commits = append(commits, hash.String())
+	}
+
+	resolver := fakeRefResolver{
+		"@channel:stable": commits[0],
+		"@channel:edge":   "refs/heads/master",
+	}
+
+	t.Run("channel to commit", func(t *testing.T) {
+		clonedRepositoryPath := t.TempDir() + "/test-repo"
+		ref := "@channel:stable"
+		s := gitsync.New(clonedRepositoryPath, config.Git{
+			Repo:      testRepositoryPath,
+			Reference: &ref,
+		}, "test-source").WithRefResolver(resolver)
+
+		result, err := s.Execute(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result["commit"] != commits[0] {
+			t.Fatalf("expected commit %s, got %v", commits[0], result["commit"])
+		}
+
+		data, err := os.ReadFile(clonedRepositoryPath + "/README")
+		if err != nil {
+			t.Fatalf("expected no error while reading file, got: %v", err)
+		}
+		if string(data) != "first commit" {
+			t.Fatalf("expected file content to be 'first commit', got: %s", string(data))
+		}
+	})
+
+	t.Run("channel to branch", func(t *testing.T) {
+		clonedRepositoryPath := t.TempDir() + "/test-repo"
+		ref := "@channel:edge"
+		s := gitsync.New(clonedRepositoryPath, config.Git{
+			Repo:      testRepositoryPath,
+			Reference: &ref,
+		}, "test-source").WithRefResolver(resolver)
+
+		result, err := s.Execute(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result["commit"] != commits[1] {
+			t.Fatalf("expected commit %s, got %v", commits[1], result["commit"])
+		}
+	})
+
+	t.Run("resolver failure", func(t *testing.T) {
+		ref := "@channel:unknown"
+		s := gitsync.New(t.TempDir()+"/test-repo", config.Git{
+			Repo:      testRepositoryPath,
+			Reference: &ref,
+		}, "test-source").WithRefResolver(resolver)
+
+		_, err := s.Execute(t.Context())
+		if err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		if !strings.Contains(err.Error(), `failed to resolve reference "@channel:unknown"`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
 // TestGitsyncSSH tests the functionality of the gitsync package with an SSH server.
 // It creates a temporary git repository, commits a file, and then uses the gitsync package to clone the repository over SSH.
 // It verifies that the cloned repository contains the expected content.
@@ -344,6 +454,162 @@ func TestGitsyncSSH(t *testing.T) {
 	})
 }
 
+// TestGitsyncSSHAgent tests cloning over SSH with the credentials held by an SSH agent. The agent is served
+// in-process on a unix socket that SSH_AUTH_SOCK points to.
+func TestGitsyncSSHAgent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SSH agent test requires unix sockets")
+	}
+
+	tmpRootDir := t.TempDir()
+
+	testRepositoryPath := tmpRootDir + "/testing"
+	repository, err := git.PlainInit(testRepositoryPath, false)
+	if err != nil {
+		t.Fatalf("expected no error while initializing test repository: %v", err)
+	}
+
+	if err := os.WriteFile(testRepositoryPath+"/README", []byte("agent commit"), 0644); err != nil {
+		t.Fatalf("expected no error while creating new file: %v", err)
+	}
+
+	w, err := repository.Worktree()
+	if err != nil {
+		t.Fatalf("expected no error while getting worktree: %v", err)
+	}
+
+	if _, err := w.Add("README"); err != nil {
+		t.Fatalf("expected no error while adding file to worktree: %v", err)
+	}
+
+	if _, err := w.Commit("README", &git.CommitOptions{Author: &object.Signature{}}); err != nil {
+		t.Fatalf("expected no error while committing changes: %v", err)
+	}
+
+	sshKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("expected no error while generating SSH key: %v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(sshKey)
+	if err != nil {
+		t.Fatalf("expected no error while creating SSH signer: %v", err)
+	}
+
+	srv, err := NewGitSSHServer("tcp", "127.0.0.1", 0, tmpRootDir, signer.PublicKey())
+	if err != nil {
+		srv, err = NewGitSSHServer("tcp6", "[::1]", 0, tmpRootDir, signer.PublicKey())
+		if err != nil {
+			t.Fatalf("expected no error while creating git SSH server: %v", err)
+		}
+	}
+
+	go func() {
+		if err := srv.Serve(); err != nil {
+			panic(fmt.Sprintf("expected no error while starting server: %v", err))
+		}
+	}()
+
+	// Unix socket paths are length limited, so don't nest the socket in t.TempDir().
+	sockDir, err := os.MkdirTemp("", "agent")
+	if err != nil {
+		t.Fatalf("expected no error while creating socket directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(sockDir) })
+
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: sshKey}); err != nil {
+		t.Fatalf("expected no error while adding key to agent: %v", err)
+	}
+
+	l, err := net.Listen("unix", filepath.Join(sockDir, "agent.sock"))
+	if err != nil {
+		t.Fatalf("expected no error while listening on agent socket: %v", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+
+	repoURL := fmt.Sprintf("ssh://git@%s/testing/.git", srv.Address().String())
+	ref := "refs/heads/master"
+
+	newSecret := func(fingerprints ...string) *config.SecretRef {
+		secret := config.Secret{
+			Name: "ssh-agent",
+			Value: map[string]any{
+				"type":         "ssh_agent",
+				"fingerprints": fingerprints,
+			},
+		}
+		return secret.Ref()
+	}
+
+	t.Run("clone with agent", func(t *testing.T) {
+		t.Setenv("SSH_AUTH_SOCK", l.Addr().String())
+
+		clonedRepositoryPath := tmpRootDir + "/test-repo"
+		s := gitsync.New(clonedRepositoryPath, config.Git{
+			Repo:        repoURL,
+			Reference:   &ref,
+			Credentials: newSecret(srv.Fingerprint()),
+		}, "test-source")
+
+		if _, err := s.Execute(t.Context()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		data, err := os.ReadFile(clonedRepositoryPath + "/README")
+		if err != nil {
+			t.Fatalf("expected no error while reading file, got: %v", err)
+		}
+
+		if string(data) != "agent commit" {
+			t.Fatalf("expected file content to be 'agent commit', got: %s", string(data))
+		}
+	})
+
+	t.Run("unknown host fingerprint", func(t *testing.T) {
+		t.Setenv("SSH_AUTH_SOCK", l.Addr().String())
+
+		s := gitsync.New(tmpRootDir+"/test-repo-fingerprint", config.Git{
+			Repo:        repoURL,
+			Reference:   &ref,
+			Credentials: newSecret("SHA256:unknown"),
+		}, "test-source")
+
+		_, err := s.Execute(t.Context())
+		if err == nil || !strings.Contains(err.Error(), "unknown fingerprint") {
+			t.Fatalf("expected unknown fingerprint error, got %v", err)
+		}
+	})
+
+	t.Run("missing SSH_AUTH_SOCK", func(t *testing.T) {
+		t.Setenv("SSH_AUTH_SOCK", "")
+
+		s := gitsync.New(tmpRootDir+"/test-repo-nosock", config.Git{
+			Repo:        repoURL,
+			Reference:   &ref,
+			Credentials: newSecret(srv.Fingerprint()),
+		}, "test-source")
+
+		_, err := s.Execute(t.Context())
+		if err == nil || !strings.Contains(err.Error(), "SSH_AUTH_SOCK") {
+			t.Fatalf("expected SSH_AUTH_SOCK error, got %v", err)
+		}
+	})
+}
+
 // mockOIDCProvider simulates an OIDC provider for client credentials flow
 func mockOIDCProvider(clientID, clientSecret string) *httptest.Server {
 	mux := http.NewServeMux()
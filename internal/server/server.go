@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -74,6 +75,7 @@ func (s *Server) Init() *Server {
 	setup("GET", "/v1/bundles", s.v1BundlesList)
 	setup("GET", "/v1/bundles/{bundle}", s.v1BundlesGet)
 	setup("PUT", "/v1/bundles/{bundle}", s.v1BundlesPut)
+	setup("DELETE", "/v1/bundles", s.v1BundlesDeleteBySelector)
 	setup("DELETE", "/v1/bundles/{bundle}", s.v1BundlesDelete)
 	setup("GET", "/v1/bundles/{bundle}/status/latest", s.v1BundleStatusLatestGet)
 	setup("GET", "/v1/bundles/{bundle}/status", s.v1BundleStatusList)
@@ -251,6 +253,36 @@ func (s *Server) v1BundlesDelete(w http.ResponseWriter, r *http.Request) {
 	JSONOK(w, resp, pretty(r))
 }
 
+// v1BundlesDeleteBySelector handles DELETE /v1/bundles?selector=key:value, deleting
+// all bundles with labels matching the selector.
+func (s *Server) v1BundlesDeleteBySelector(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	selector, err := selectorParam(r.URL)
+	if err != nil {
+		ErrorString(w, http.StatusBadRequest, types.CodeInvalidParameter, err)
+		return
+	}
+	if selector.Len() == 0 {
+		ErrorString(w, http.StatusBadRequest, types.CodeInvalidParameter, errors.New("selector parameter is required"))
+		return
+	}
+
+	principal, tenant := s.auth(r)
+	deleted, err := s.db.DeleteBundlesBySelector(ctx, principal, tenant, selector, getBoolParam(r.URL, types.ParamSkipUnauthorizedV1, true))
+	if err != nil {
+		errorAuto(w, err)
+		return
+	}
+
+	if deleted == nil {
+		deleted = []string{}
+	}
+
+	resp := types.BundlesDeleteBySelectorResponseV1{Result: deleted}
+	JSONOK(w, resp, pretty(r))
+}
+
 func (s *Server) v1BundleStatusLatestGet(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -700,6 +732,34 @@ func (*Server) listOptions(r *http.Request) database.ListOptions {
 	return opts
 }
 
+// selectorParam parses the (repeated) selector query parameter into a label selector.
+// Each value is either "key:value", where value may be a glob pattern, or "key", which
+// matches any bundle that has the label set. Multiple values for the same key match
+// if any of them matches; all keys must match.
+func selectorParam(u *url.URL) (config.Selector, error) {
+	values := make(map[string][]string)
+	for _, p := range u.Query()[types.ParamSelectorV1] {
+		key, value, found := strings.Cut(p, ":")
+		if key == "" {
+			return config.Selector{}, fmt.Errorf("invalid selector %q", p)
+		}
+		if _, ok := values[key]; !ok {
+			values[key] = []string{}
+		}
+		if found {
+			values[key] = append(values[key], value)
+		}
+	}
+
+	var selector config.Selector
+	for key, vs := range values {
+		if err := selector.Set(key, vs); err != nil {
+			return config.Selector{}, err
+		}
+	}
+	return selector, nil
+}
+
 func errorAuto(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, database.ErrNotAuthorized):
@@ -501,6 +501,88 @@ func TestServerBundleOwners(t *testing.T) {
 	}
 }
 
+func TestServerBundlesDeleteBySelector(t *testing.T) {
+	ctx := t.Context()
+
+	for databaseType, databaseConfig := range dbs.Configs(t) {
+		t.Run(databaseType, func(t *testing.T) {
+			t.Parallel()
+			var ctr testcontainers.Container
+			if databaseConfig.Setup != nil {
+				ctr = databaseConfig.Setup(t)
+				t.Cleanup(databaseConfig.Cleanup(t, ctr))
+			}
+
+			db := initTestDB(t, databaseConfig.Database(t, ctr).Database)
+			ts := initTestServer(t, db)
+			defer ts.Close()
+
+			if err := db.UpsertPrincipal(ctx, principal); err != nil {
+				t.Fatal(err)
+			}
+
+			const ownerKey = "test-owner-key"
+			const ownerKey2 = "test-owner-key2"
+
+			if err := db.UpsertToken(ctx, "internal", "default", &config.Token{Name: "testowner", APIKey: ownerKey, Scopes: []config.Scope{{Role: "owner"}}}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := db.UpsertToken(ctx, "internal", "default", &config.Token{Name: "testowner2", APIKey: ownerKey2, Scopes: []config.Scope{{Role: "owner"}}}); err != nil {
+				t.Fatal(err)
+			}
+
+			ts.Request("PUT", "/v1/bundles/dev-a", `{"labels": {"env": "dev"}}`, ownerKey).ExpectStatus(200)
+			ts.Request("PUT", "/v1/bundles/dev-b", `{"labels": {"env": "dev", "team": "x"}}`, ownerKey).ExpectStatus(200)
+			ts.Request("PUT", "/v1/bundles/prod", `{"labels": {"env": "prod"}}`, ownerKey).ExpectStatus(200)
+			ts.Request("PUT", "/v1/bundles/dev-c", `{"labels": {"env": "dev"}}`, ownerKey2).ExpectStatus(200)
+
+			listNames := func(key string) []string {
+				var list types.BundlesListResponseV1
+				ts.Request("GET", "/v1/bundles", "", key).ExpectStatus(200).ExpectBody(&list)
+				names := []string{}
+				for _, b := range list.Result {
+					names = append(names, b.Name)
+				}
+				return names
+			}
+
+			ts.Request("DELETE", "/v1/bundles", "", ownerKey).ExpectStatus(400)
+			ts.Request("DELETE", "/v1/bundles?selector=:dev", "", ownerKey).ExpectStatus(400)
+
+			{ // failing on unauthorized matches deletes nothing
+				ts.Request("DELETE", "/v1/bundles?selector=env:dev&skip_unauthorized=false", "", ownerKey).ExpectStatus(403)
+				if diff := cmp.Diff([]string{"dev-a", "dev-b", "prod"}, listNames(ownerKey)); diff != "" {
+					t.Fatal("unexpected bundles (-want,+got)", diff)
+				}
+			}
+
+			{ // no matches
+				var resp types.BundlesDeleteBySelectorResponseV1
+				ts.Request("DELETE", "/v1/bundles?selector=env:staging", "", ownerKey).ExpectStatus(200).ExpectBody(&resp)
+				if diff := cmp.Diff([]string{}, resp.Result); diff != "" {
+					t.Fatal("unexpected response (-want,+got)", diff)
+				}
+			}
+
+			{ // skipping unauthorized matches
+				var resp types.BundlesDeleteBySelectorResponseV1
+				ts.Request("DELETE", "/v1/bundles?selector=env:dev", "", ownerKey).ExpectStatus(200).ExpectBody(&resp)
+				if diff := cmp.Diff([]string{"dev-a", "dev-b"}, resp.Result); diff != "" {
+					t.Fatal("unexpected response (-want,+got)", diff)
+				}
+			}
+
+			if diff := cmp.Diff([]string{"prod"}, listNames(ownerKey)); diff != "" {
+				t.Fatal("unexpected bundles (-want,+got)", diff)
+			}
+			if diff := cmp.Diff([]string{"dev-c"}, listNames(ownerKey2)); diff != "" {
+				t.Fatal("unexpected bundles (-want,+got)", diff)
+			}
+		})
+	}
+}
+
 func TestServerSourceOwners(t *testing.T) {
 	ctx := t.Context()
 	for databaseType, databaseConfig := range dbs.Configs(t) {
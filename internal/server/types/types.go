@@ -18,7 +18,9 @@ const (
 )
 
 const (
-	ParamPrettyV1 = "pretty"
+	ParamPrettyV1           = "pretty"
+	ParamSelectorV1         = "selector"
+	ParamSkipUnauthorizedV1 = "skip_unauthorized"
 )
 
 type HealthResponse struct {
@@ -58,6 +60,10 @@ type BundlesPutResponseV1 struct{}
 
 type BundlesDeleteResponseV1 struct{}
 
+type BundlesDeleteBySelectorResponseV1 struct {
+	Result []string `json:"result"`
+}
+
 type SourcesGetResponseV1 struct {
 	Result *config.Source `json:"result,omitempty"`
 }
@@ -97,11 +97,36 @@ func (s *Source) AddFS(f fs.FS) {
 
 // Transform applies Rego policies to data, replacing the original content with the
 // transformed content.
-func (s *Source) Transform(ctx context.Context) (*bytes.Buffer, error) {
+//
+// The data files of any given required sources are loaded alongside the source's
+// own files, so that transform queries can look up reference data from them. Their
+// policies are not loaded. Required sources are loaded as they are on disk, i.e.
+// without the mounts of the requirement applied.
+func (s *Source) Transform(ctx context.Context, required ...*Source) (*bytes.Buffer, error) {
 	paths := make([]string, len(s.dirs))
 	for i, dir := range s.dirs {
 		paths[i] = dir.Path
 	}
+
+	var requiredPaths []string
+	for _, req := range required {
+		for _, dir := range req.dirs {
+			if !slices.Contains(paths, dir.Path) && !slices.Contains(requiredPaths, dir.Path) {
+				requiredPaths = append(requiredPaths, dir.Path)
+			}
+		}
+	}
+	paths = append(paths, requiredPaths...)
+
+	filter := func(abspath string, info fs.FileInfo, _ int) bool {
+		if info.IsDir() || !strings.HasSuffix(abspath, ".rego") {
+			return false
+		}
+		return slices.ContainsFunc(requiredPaths, func(p string) bool {
+			return strings.HasPrefix(filepath.ToSlash(abspath), strings.TrimSuffix(p, "/")+"/")
+		})
+	}
+
 	buf := bytes.Buffer{}
 
 	for _, t := range s.Transforms {
@@ -117,7 +142,7 @@ func (s *Source) Transform(ctx context.Context) (*bytes.Buffer, error) {
 
 		q, err := rego.New(
 			rego.Query(t.Query),
-			rego.Load(paths, nil),
+			rego.Load(paths, filter),
 			rego.Capabilities(offlineCaps),
 			rego.EnablePrintStatements(true),
 			rego.PrintHook(topdown.NewPrintHook(&buf)),
@@ -172,6 +197,7 @@ type Builder struct {
 	optimizationLevel int
 	revision          string
 	revisionFunc      func(fs.FS) (string, error)
+	maxExpansions     int
 }
 
 func New() *Builder {
@@ -215,6 +241,14 @@ func (b *Builder) WithRevisionFunc(fn func(fs.FS) (string, error)) *Builder {
 	return b
 }
 
+// WithMaxExpansions limits the number of (source, mounts) combinations processed
+// while expanding the requirements of a build. Builds exceeding the limit are
+// aborted. Zero means unlimited.
+func (b *Builder) WithMaxExpansions(n int) *Builder {
+	b.maxExpansions = n
+	return b
+}
+
 func (b *Builder) Revision() string {
 	return b.revision
 }
@@ -404,6 +438,9 @@ func (b *Builder) Build(ctx context.Context) error {
 				this.mounts = append(this.mounts, next.mounts...)
 
 				if !slices.ContainsFunc(alreadyProcessed, this.Equal) {
+					if b.maxExpansions > 0 && len(alreadyProcessed) >= b.maxExpansions {
+						return fmt.Errorf("requirement %q of source %q exceeds the maximum of %d requirement expansions", src.Name, next.src.Name, b.maxExpansions)
+					}
 					toProcess = append(toProcess, this)               // queue it
 					alreadyProcessed = append(alreadyProcessed, this) // record "dealt with this"
 				}
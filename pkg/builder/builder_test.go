@@ -900,6 +900,94 @@ func TestBuilder(t *testing.T) {
 
 }
 
+func TestBuilderMaxExpansions(t *testing.T) {
+	files := map[string]string{}
+	for i := range 6 {
+		files[fmt.Sprintf("/src%d/p%d/x.rego", i, i)] = fmt.Sprintf("package p%d\np := %d", i, i)
+	}
+
+	tempfs.WithTempFS(t, files, func(t *testing.T, root string) {
+		// src0 -> src1 -> ... -> src5
+		var srcs []*builder.Source
+		for i := range 6 {
+			s := builder.NewSource(fmt.Sprintf("src%d", i))
+			if i < 5 {
+				next := fmt.Sprintf("src%d", i+1)
+				s.Requirements = []config.Requirement{{Source: &next}}
+			}
+			if err := s.AddDir(builder.Dir{Path: fmt.Sprintf("%v/src%d", root, i)}); err != nil {
+				t.Fatal(err)
+			}
+			srcs = append(srcs, s)
+		}
+
+		err := builder.New().
+			WithSources(srcs).
+			WithOutput(bytes.NewBuffer(nil)).
+			WithMaxExpansions(3).
+			Build(t.Context())
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		exp := `requirement "src4" of source "src3" exceeds the maximum of 3 requirement expansions`
+		if err.Error() != exp {
+			t.Fatalf("expected error %q, got %q", exp, err.Error())
+		}
+
+		if err := builder.New().
+			WithSources(srcs).
+			WithOutput(bytes.NewBuffer(nil)).
+			WithMaxExpansions(5).
+			Build(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestTransformWithRequiredSources(t *testing.T) {
+	files := map[string]string{
+		"/a/users/data.json":  `[{"name": "alice", "team": "t1"}, {"name": "bob", "team": "t2"}]`,
+		"/b/teams/data.json":  `{"t1": "platform", "t2": "security"}`,
+		"/b/teams/teams.rego": "package teams\nthis is not valid rego",
+	}
+
+	tempfs.WithTempFS(t, files, func(t *testing.T, root string) {
+		b := builder.NewSource("b")
+		if err := b.AddDir(builder.Dir{Path: root + "/b"}); err != nil {
+			t.Fatal(err)
+		}
+
+		bName := "b"
+		a := builder.NewSource("a")
+		a.Requirements = []config.Requirement{{Source: &bName}}
+		if err := a.AddDir(builder.Dir{Path: root + "/a"}); err != nil {
+			t.Fatal(err)
+		}
+		a.Transforms = []builder.Transform{{
+			Query: `{name: team | u := input[_]; name := u.name; team := data.teams[u.team]}`,
+			Path:  root + "/a/users/data.json",
+		}}
+
+		if _, err := a.Transform(t.Context(), b); err != nil {
+			t.Fatal(err)
+		}
+
+		bs, err := os.ReadFile(root + "/a/users/data.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var act map[string]string
+		if err := json.Unmarshal(bs, &act); err != nil {
+			t.Fatal(err)
+		}
+		exp := map[string]string{"alice": "platform", "bob": "security"}
+		if diff := cmp.Diff(exp, act); diff != "" {
+			t.Errorf("transformed data: (-want,+got)\n%s", diff)
+		}
+	})
+}
+
 func trimLeadingWhitespace(input string) string {
 	lines := strings.Split(input, "\n")
 	for i, line := range lines {
@@ -0,0 +1,190 @@
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/bundle" // nolint:staticcheck
+)
+
+// BundleDiff describes the changes between two built bundles. File paths are
+// the paths of the policy, plan and wasm files within the bundles; data paths
+// are slash-separated paths into the bundle's data document.
+type BundleDiff struct {
+	Added        []string `json:"added,omitempty"`
+	Removed      []string `json:"removed,omitempty"`
+	Modified     []string `json:"modified,omitempty"`
+	DataChanged  []string `json:"data_changed,omitempty"`
+	RootsAdded   []string `json:"roots_added,omitempty"`
+	RootsRemoved []string `json:"roots_removed,omitempty"`
+}
+
+// Empty returns true if the diff contains no changes.
+func (d *BundleDiff) Empty() bool {
+	return len(d.Added) == 0 &&
+		len(d.Removed) == 0 &&
+		len(d.Modified) == 0 &&
+		len(d.DataChanged) == 0 &&
+		len(d.RootsAdded) == 0 &&
+		len(d.RootsRemoved) == 0
+}
+
+// DiffOption configures how two bundles are compared by Diff.
+type DiffOption func(*differ)
+
+// DiffIgnoreRegoFormatting makes Diff compare policy files by their parsed
+// AST rather than their text, so that reformatted but otherwise identical
+// policies are not reported as modified.
+func DiffIgnoreRegoFormatting() DiffOption {
+	return func(d *differ) {
+		d.ignoreFormatting = true
+	}
+}
+
+type differ struct {
+	ignoreFormatting bool
+}
+
+// Diff reads the two bundle tarballs and reports the files that have been added,
+// removed or modified, the data paths that changed, and the changes to the
+// bundle roots when going from oldBundle to newBundle.
+func Diff(oldBundle, newBundle io.Reader, opts ...DiffOption) (*BundleDiff, error) {
+	var d differ
+	for _, opt := range opts {
+		opt(&d)
+	}
+
+	a, err := bundle.NewReader(oldBundle).Read()
+	if err != nil {
+		return nil, fmt.Errorf("old bundle: %w", err)
+	}
+	b, err := bundle.NewReader(newBundle).Read()
+	if err != nil {
+		return nil, fmt.Errorf("new bundle: %w", err)
+	}
+
+	return d.diff(&a, &b), nil
+}
+
+func (d *differ) diff(a, b *bundle.Bundle) *BundleDiff {
+	var result BundleDiff
+
+	oldModules := modulesByPath(a)
+	newModules := modulesByPath(b)
+	for _, path := range slices.Sorted(maps.Keys(oldModules)) {
+		if _, ok := newModules[path]; !ok {
+			result.Removed = append(result.Removed, path)
+		}
+	}
+	for _, path := range slices.Sorted(maps.Keys(newModules)) {
+		prev, ok := oldModules[path]
+		if !ok {
+			result.Added = append(result.Added, path)
+		} else if !d.moduleEqual(prev, newModules[path]) {
+			result.Modified = append(result.Modified, path)
+		}
+	}
+
+	oldFiles := rawFilesByPath(a)
+	newFiles := rawFilesByPath(b)
+	for _, path := range slices.Sorted(maps.Keys(oldFiles)) {
+		if _, ok := newFiles[path]; !ok {
+			result.Removed = append(result.Removed, path)
+		}
+	}
+	for _, path := range slices.Sorted(maps.Keys(newFiles)) {
+		prev, ok := oldFiles[path]
+		if !ok {
+			result.Added = append(result.Added, path)
+		} else if !bytes.Equal(prev, newFiles[path]) {
+			result.Modified = append(result.Modified, path)
+		}
+	}
+
+	result.DataChanged = diffData(nil, a.Data, b.Data)
+
+	var oldRoots, newRoots []string
+	if a.Manifest.Roots != nil {
+		oldRoots = *a.Manifest.Roots
+	}
+	if b.Manifest.Roots != nil {
+		newRoots = *b.Manifest.Roots
+	}
+	for _, root := range newRoots {
+		if !slices.Contains(oldRoots, root) {
+			result.RootsAdded = append(result.RootsAdded, root)
+		}
+	}
+	for _, root := range oldRoots {
+		if !slices.Contains(newRoots, root) {
+			result.RootsRemoved = append(result.RootsRemoved, root)
+		}
+	}
+	slices.Sort(result.RootsAdded)
+	slices.Sort(result.RootsRemoved)
+
+	return &result
+}
+
+func (d *differ) moduleEqual(a, b bundle.ModuleFile) bool {
+	if bytes.Equal(a.Raw, b.Raw) {
+		return true
+	}
+	if d.ignoreFormatting && a.Parsed != nil && b.Parsed != nil {
+		return a.Parsed.Equal(b.Parsed)
+	}
+	return false
+}
+
+func modulesByPath(b *bundle.Bundle) map[string]bundle.ModuleFile {
+	m := make(map[string]bundle.ModuleFile, len(b.Modules))
+	for _, mf := range b.Modules {
+		m[mf.Path] = mf
+	}
+	return m
+}
+
+// rawFilesByPath returns the plan and wasm files of the bundle.
+func rawFilesByPath(b *bundle.Bundle) map[string][]byte {
+	m := make(map[string][]byte, len(b.PlanModules)+len(b.WasmModules))
+	for _, pf := range b.PlanModules {
+		m[pf.Path] = pf.Raw
+	}
+	for _, wf := range b.WasmModules {
+		m[wf.Path] = wf.Raw
+	}
+	return m
+}
+
+// diffData returns the paths of the values that differ between a and b. Objects
+// present on both sides are descended into, so that the deepest differing paths
+// are reported.
+func diffData(path []string, a, b any) []string {
+	objA, okA := a.(map[string]any)
+	objB, okB := b.(map[string]any)
+	if !okA || !okB {
+		if reflect.DeepEqual(a, b) {
+			return nil
+		}
+		return []string{"/" + strings.Join(path, "/")}
+	}
+
+	keys := slices.Collect(maps.Keys(objA))
+	for k := range objB {
+		if _, ok := objA[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var changed []string
+	for _, k := range keys {
+		changed = append(changed, diffData(append(slices.Clone(path), k), objA[k], objB[k])...)
+	}
+	return changed
+}
@@ -0,0 +1,108 @@
+package builder_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-policy-agent/opa/ast"    // nolint:staticcheck
+	"github.com/open-policy-agent/opa/bundle" // nolint:staticcheck
+
+	"github.com/open-policy-agent/opa-control-plane/pkg/builder"
+)
+
+func TestDiff(t *testing.T) {
+	oldBundle := writeBundle(t, []string{"w", "x", "y"}, map[string]string{
+		"/x/x.rego":      "package x\n\np := 7\n",
+		"/x/fmt.rego":    "package x\n\nq := 1\n",
+		"/y/y.rego":      "package y\n\np := 1\n",
+		"/y/remove.rego": "package y\n\nr := 1\n",
+	}, map[string]any{
+		"x": map[string]any{"a": 1, "b": map[string]any{"c": true}},
+		"y": map[string]any{"keep": "same"},
+	})
+	newBundle := writeBundle(t, []string{"x", "y", "z"}, map[string]string{
+		"/x/x.rego":   "package x\n\np := 8\n",
+		"/x/fmt.rego": "package x\nq   :=   1",
+		"/y/y.rego":   "package y\n\np := 1\n",
+		"/z/add.rego": "package z\n\np := 1\n",
+	}, map[string]any{
+		"x": map[string]any{"a": 1, "b": map[string]any{"c": false}},
+		"y": map[string]any{"keep": "same"},
+		"z": map[string]any{"new": 1},
+	})
+
+	tests := []struct {
+		note string
+		opts []builder.DiffOption
+		exp  builder.BundleDiff
+	}{
+		{
+			note: "textual",
+			exp: builder.BundleDiff{
+				Added:        []string{"/z/add.rego"},
+				Removed:      []string{"/y/remove.rego"},
+				Modified:     []string{"/x/fmt.rego", "/x/x.rego"},
+				DataChanged:  []string{"/x/b/c", "/z"},
+				RootsAdded:   []string{"z"},
+				RootsRemoved: []string{"w"},
+			},
+		},
+		{
+			note: "ignore rego formatting",
+			opts: []builder.DiffOption{builder.DiffIgnoreRegoFormatting()},
+			exp: builder.BundleDiff{
+				Added:        []string{"/z/add.rego"},
+				Removed:      []string{"/y/remove.rego"},
+				Modified:     []string{"/x/x.rego"},
+				DataChanged:  []string{"/x/b/c", "/z"},
+				RootsAdded:   []string{"z"},
+				RootsRemoved: []string{"w"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.note, func(t *testing.T) {
+			act, err := builder.Diff(bytes.NewReader(oldBundle), bytes.NewReader(newBundle), tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.exp, *act); diff != "" {
+				t.Errorf("diff: (-want,+got)\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		act, err := builder.Diff(bytes.NewReader(oldBundle), bytes.NewReader(oldBundle))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !act.Empty() {
+			t.Errorf("expected empty diff, got %+v", act)
+		}
+	})
+}
+
+func writeBundle(t *testing.T, roots []string, modules map[string]string, data map[string]any) []byte {
+	t.Helper()
+
+	b := bundle.Bundle{
+		Manifest: bundle.Manifest{Roots: &roots},
+		Data:     data,
+	}
+	for path, src := range modules {
+		b.Modules = append(b.Modules, bundle.ModuleFile{
+			Path:   path,
+			Raw:    []byte(src),
+			Parsed: ast.MustParseModule(src),
+		})
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := bundle.Write(buf, b); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
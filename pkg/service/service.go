@@ -69,6 +69,7 @@ type Service struct {
 	initialized    bool
 	storage        ext_os.ObjectStorage
 	secretFactory  pkgsync.SecretProviderFactory
+	refResolver    pkgsync.RefResolver
 	authorizer     ext_authz.Authorizer
 	metrics        *metrics.Metrics
 }
@@ -238,6 +239,13 @@ func (s *Service) WithSecretProviderFactory(factory pkgsync.SecretProviderFactor
 	return s
 }
 
+// WithRefResolver configures the resolver used by git sources to resolve their
+// configured reference before clone and checkout.
+func (s *Service) WithRefResolver(resolver pkgsync.RefResolver) *Service {
+	s.refResolver = resolver
+	return s
+}
+
 func (s *Service) Init(ctx context.Context) error {
 	if s.initialized {
 		return nil
@@ -479,7 +487,7 @@ func (s *Service) launchWorkers(ctx context.Context) {
 					SyncBuiltin(&syncs, dep.Builtin, s.builtinFS, join(srcDir, "builtin")).
 					SyncSourceSQL(&syncs, dep.ID, dep.Name, &s.database, join(srcDir, "database"), metadataFields[dep.Name]).
 					SyncDatasources(&syncs, dep.Name, dep.Datasources, join(srcDir, "datasources"), tenantProvider, metadataFields[dep.Name]).
-					SyncGit(&syncs, dep.Name, dep.Git, join(srcDir, "repo"), overrides[dep.Name], tenantProvider, s.metrics).
+					SyncGit(&syncs, dep.Name, dep.Git, join(srcDir, "repo"), overrides[dep.Name], tenantProvider, s.refResolver, s.metrics).
 					AddRequirements(dep.Requirements)
 
 				sources = append(sources, &src.Source)
@@ -596,7 +604,7 @@ func (src *source) addFS(fsys fs.FS) {
 	src.Source.AddFS(fsys)
 }
 
-func (src *source) SyncGit(syncs *[]sourceSynchronizer, sourceName string, git config.Git, repoDir string, reqCommit string, provider pkgsync.SecretProvider, m *metrics.Metrics) *source {
+func (src *source) SyncGit(syncs *[]sourceSynchronizer, sourceName string, git config.Git, repoDir string, reqCommit string, provider pkgsync.SecretProvider, resolver pkgsync.RefResolver, m *metrics.Metrics) *source {
 	if git.Repo != "" {
 		srcDir := repoDir
 		if git.Path != nil {
@@ -607,7 +615,7 @@ func (src *source) SyncGit(syncs *[]sourceSynchronizer, sourceName string, git c
 			git.Commit = &reqCommit
 		}
 		*syncs = append(*syncs, sourceSynchronizer{
-			sync:       gitsync.New(repoDir, git, sourceName).WithSecretProvider(provider).WithMetrics(m),
+			sync:       gitsync.New(repoDir, git, sourceName).WithSecretProvider(provider).WithRefResolver(resolver).WithMetrics(m),
 			sourceName: sourceName,
 			sourceType: "git",
 		})
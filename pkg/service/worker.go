@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"io/fs"
+	"slices"
 	"time"
 
 	"github.com/open-policy-agent/opa-control-plane/internal/config"
@@ -184,7 +185,7 @@ func (w *BundleWorker) Execute(ctx context.Context) time.Time {
 	}
 
 	for _, src := range w.sources {
-		buf, err := src.Transform(ctx)
+		buf, err := src.Transform(ctx, w.requiredSources(src)...)
 		if buf != nil && buf.Len() > 0 {
 			w.log.Debugf("transform %q: %s", src.Name, buf.String())
 		}
@@ -254,6 +255,35 @@ func (w *BundleWorker) Execute(ctx context.Context) time.Time {
 	return w.report(ctx, BuildStateSuccess, BuildPhaseBuild, resolvedRevision, startTime, nil)
 }
 
+// requiredSources returns the sources transitively required by src, so that its
+// transforms can refer to their data.
+func (w *BundleWorker) requiredSources(src *builder.Source) []*builder.Source {
+	byName := make(map[string]*builder.Source, len(w.sources))
+	for _, s := range w.sources {
+		byName[s.Name] = s
+	}
+
+	var required []*builder.Source
+	visited := map[string]struct{}{src.Name: {}}
+	rs := slices.Clone(src.Requirements)
+	for len(rs) > 0 {
+		var next config.Requirement
+		next, rs = rs[0], rs[1:]
+		if next.Source == nil {
+			continue
+		}
+		if _, ok := visited[*next.Source]; ok {
+			continue
+		}
+		visited[*next.Source] = struct{}{}
+		if dep, ok := byName[*next.Source]; ok {
+			required = append(required, dep)
+			rs = append(rs, dep.Requirements...)
+		}
+	}
+	return required
+}
+
 func (w *BundleWorker) report(ctx context.Context, state BuildState, phase BuildPhase, revision string, startTime time.Time, err error) time.Time {
 	interval := w.interval
 	w.status.State = state
@@ -56,6 +56,12 @@ type SecretProvider interface {
 	//       "passphrase": "optional-passphrase",
 	//       "fingerprints": ["SHA256:..."]  // optional
 	//     }
+	//   - SSH Agent ("ssh_agent"), using the agent listening on SSH_AUTH_SOCK:
+	//     {
+	//       "type": "ssh_agent",
+	//       "user": "git",                   // optional
+	//       "fingerprints": ["SHA256:..."]  // optional
+	//     }
 	//   - Basic Auth ("basic_auth"):
 	//     {
 	//       "type": "basic_auth",
@@ -112,3 +118,20 @@ type SecretProvider interface {
 type SecretProviderFactory interface {
 	SecretProviderForTenant(ctx context.Context, tenant string) (SecretProvider, error)
 }
+
+// RefResolver resolves git reference expressions to concrete references.
+// External projects implement this interface to map symbolic references
+// (e.g. a release channel such as "@channel:stable") to a branch, tag or
+// commit maintained in an external system.
+//
+// The git synchronizer calls the resolver before cloning or checking out a
+// repository. If the returned value is a full commit hash, that commit is
+// checked out; otherwise it is used as the git reference (e.g.
+// "refs/heads/main"). Resolvers should return references they do not
+// recognize unchanged.
+type RefResolver interface {
+	// ResolveRef returns the concrete commit hash or reference for the
+	// given reference expression. Returns an error if the reference cannot
+	// be resolved, which fails the synchronization.
+	ResolveRef(ctx context.Context, reference string) (string, error)
+}